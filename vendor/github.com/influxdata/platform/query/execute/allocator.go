@@ -0,0 +1,27 @@
+package execute
+
+import "sync/atomic"
+
+// Allocator tracks how many bytes a transformation has requested from the
+// query engine's memory budget for the current query.
+type Allocator interface {
+	// Allocated reports the number of bytes currently accounted for by
+	// this allocator.
+	Allocated() int64
+}
+
+// CountingAllocator is a simple Allocator that sums whatever is reported
+// to it through Account.
+type CountingAllocator struct {
+	n int64
+}
+
+func (a *CountingAllocator) Allocated() int64 {
+	return atomic.LoadInt64(&a.n)
+}
+
+// Account adds delta (which may be negative) to the allocator's running
+// total.
+func (a *CountingAllocator) Account(delta int64) {
+	atomic.AddInt64(&a.n, delta)
+}
@@ -0,0 +1,100 @@
+package execute
+
+import "sort"
+
+// BlockBuilder accumulates the rows of a block so a transformation can
+// rearrange them before they are emitted downstream.
+type BlockBuilder interface {
+	NCols() int
+	NRows() int
+
+	// ColIndex returns the position of label among the builder's columns,
+	// or -1 if it isn't one of them.
+	ColIndex(label string) int
+
+	AppendRow(row Row)
+	Row(i int) Row
+
+	// SortWithOptions reorders the builder's rows according to cols, with
+	// one direction and null-ordering flag per key, in the same order as
+	// cols. The sort is stable: rows that compare equal on every key keep
+	// their original relative order.
+	SortWithOptions(cols []string, desc, nullsFirst []bool)
+
+	// ClearData drops all rows currently held by the builder while
+	// keeping its column schema, so it can be reused for the next run
+	// without re-adding columns.
+	ClearData()
+
+	// Truncate drops every row from index n onward, keeping the first n.
+	// It lets a caller stage a chunk's rows just long enough to consume
+	// them (e.g. push them through a selector) and then discard that
+	// chunk without clearing rows appended before it.
+	Truncate(n int)
+}
+
+// Row is a single row's worth of opaque, comparable cell values, indexed
+// in the same order as its builder's columns.
+type Row []interface{}
+
+// RowBuilder is a minimal in-memory BlockBuilder. The sort transformation
+// uses the BlockBuilder interface so its sharding and spilling helpers
+// can be exercised against RowBuilder in tests without the rest of the
+// query engine.
+type RowBuilder struct {
+	cols []string
+	rows []Row
+}
+
+func NewRowBuilder(cols []string) *RowBuilder {
+	return &RowBuilder{cols: cols}
+}
+
+func (b *RowBuilder) NCols() int { return len(b.cols) }
+func (b *RowBuilder) NRows() int { return len(b.rows) }
+
+func (b *RowBuilder) ColIndex(label string) int {
+	for i, c := range b.cols {
+		if c == label {
+			return i
+		}
+	}
+	return -1
+}
+
+func (b *RowBuilder) AppendRow(row Row) {
+	b.rows = append(b.rows, row)
+}
+
+func (b *RowBuilder) Row(i int) Row {
+	return b.rows[i]
+}
+
+func (b *RowBuilder) ClearData() {
+	b.rows = b.rows[:0]
+}
+
+func (b *RowBuilder) Truncate(n int) {
+	b.rows = b.rows[:n]
+}
+
+func (b *RowBuilder) SortWithOptions(cols []string, desc, nullsFirst []bool) {
+	sortRows(b.rows, colIndices(b, cols), desc, nullsFirst)
+}
+
+// colIndices resolves each of cols to its position in builder.
+func colIndices(builder BlockBuilder, cols []string) []int {
+	idx := make([]int, len(cols))
+	for i, c := range cols {
+		idx[i] = builder.ColIndex(c)
+	}
+	return idx
+}
+
+// sortRows stably sorts rows in place using the multi-key comparator
+// described by idx/desc/nullsFirst.
+func sortRows(rows []Row, idx []int, desc, nullsFirst []bool) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		return lessRow(rows[i], rows[j], idx, desc, nullsFirst)
+	})
+}
@@ -0,0 +1,61 @@
+package execute
+
+import "reflect"
+import "testing"
+
+func TestRowBuilderSortWithOptionsStable(t *testing.T) {
+	b := NewRowBuilder([]string{"_value", "orig"})
+	for i, v := range []int64{1, 2, 1, 2, 1} {
+		b.AppendRow(Row{v, i})
+	}
+
+	b.SortWithOptions([]string{"_value"}, []bool{false}, []bool{false})
+
+	var got []int
+	for i := 0; i < b.NRows(); i++ {
+		got = append(got, b.Row(i)[1].(int))
+	}
+	// Equal keys (the 1s, then the 2s) must keep their original relative order.
+	want := []int{0, 2, 4, 1, 3}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestRowBuilderSortWithOptionsNulls(t *testing.T) {
+	b := NewRowBuilder([]string{"_value"})
+	for _, v := range []interface{}{int64(2), nil, int64(1), nil} {
+		b.AppendRow(Row{v})
+	}
+
+	b.SortWithOptions([]string{"_value"}, []bool{false}, []bool{true})
+
+	var got []interface{}
+	for i := 0; i < b.NRows(); i++ {
+		got = append(got, b.Row(i)[0])
+	}
+	want := []interface{}{nil, nil, int64(1), int64(2)}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestRowBuilderTruncate(t *testing.T) {
+	b := NewRowBuilder([]string{"_value"})
+	for _, v := range []int64{1, 2, 3} {
+		b.AppendRow(Row{v})
+	}
+
+	b.Truncate(1)
+	if got := b.NRows(); got != 1 {
+		t.Fatalf("got %d rows, want 1", got)
+	}
+	if got := b.Row(0)[0].(int64); got != 1 {
+		t.Fatalf("got row 0 = %v, want 1", got)
+	}
+
+	b.AppendRow(Row{int64(4)})
+	if got := b.NRows(); got != 2 {
+		t.Fatalf("got %d rows after append, want 2", got)
+	}
+}
@@ -0,0 +1,91 @@
+package execute
+
+import "time"
+
+// lessRow reports whether a sorts before b according to the multi-key
+// comparator described by idx (column indices into each row, in priority
+// order), desc (per-key direction) and nullsFirst (per-key null
+// ordering). A nil cell is treated as that column's null value.
+func lessRow(a, b Row, idx []int, desc, nullsFirst []bool) bool {
+	for k, i := range idx {
+		av, bv := a[i], b[i]
+		if av == nil || bv == nil {
+			if av == nil && bv == nil {
+				continue
+			}
+			if av == nil {
+				return nullsFirst[k]
+			}
+			return !nullsFirst[k]
+		}
+
+		switch c := compareValue(av, bv); {
+		case c < 0:
+			return !desc[k]
+		case c > 0:
+			return desc[k]
+		default:
+			continue
+		}
+	}
+	return false
+}
+
+// compareValue orders two non-nil cell values of the same underlying
+// type, returning -1, 0 or 1.
+func compareValue(a, b interface{}) int {
+	switch av := a.(type) {
+	case float64:
+		bv := b.(float64)
+		switch {
+		case av < bv:
+			return -1
+		case av > bv:
+			return 1
+		default:
+			return 0
+		}
+	case int64:
+		bv := b.(int64)
+		switch {
+		case av < bv:
+			return -1
+		case av > bv:
+			return 1
+		default:
+			return 0
+		}
+	case string:
+		bv := b.(string)
+		switch {
+		case av < bv:
+			return -1
+		case av > bv:
+			return 1
+		default:
+			return 0
+		}
+	case bool:
+		bv := b.(bool)
+		switch {
+		case !av && bv:
+			return -1
+		case av && !bv:
+			return 1
+		default:
+			return 0
+		}
+	case time.Time:
+		bv := b.(time.Time)
+		switch {
+		case av.Before(bv):
+			return -1
+		case av.After(bv):
+			return 1
+		default:
+			return 0
+		}
+	default:
+		return 0
+	}
+}
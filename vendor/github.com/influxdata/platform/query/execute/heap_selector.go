@@ -0,0 +1,101 @@
+package execute
+
+import "container/heap"
+
+// HeapSelector retains only the n most extreme rows pushed to it, ordered
+// by a multi-key comparator, using a bounded heap whose root is the worst
+// retained row. This keeps memory at O(n) and time at O(N log n) instead
+// of the O(N log N) a full sort would cost for a "top-K by column" query.
+type HeapSelector struct {
+	colIdx     []int
+	desc       []bool
+	nullsFirst []bool
+	n          int
+
+	rows rowHeap
+}
+
+// NewHeapSelector returns a selector that retains the n rows that sort
+// earliest according to colIdx/desc/nullsFirst (the same per-key
+// comparator SortWithOptions uses), where colIdx holds each sort key's
+// index into the rows that will be pushed.
+func NewHeapSelector(colIdx []int, desc, nullsFirst []bool, n int) *HeapSelector {
+	return &HeapSelector{
+		colIdx:     colIdx,
+		desc:       desc,
+		nullsFirst: nullsFirst,
+		n:          n,
+		rows:       make(rowHeap, 0, n),
+	}
+}
+
+// compare returns -1, 0 or 1 according to whether a sorts before, equal
+// to, or after b.
+func (h *HeapSelector) compare(a, b Row) int {
+	switch {
+	case lessRow(a, b, h.colIdx, h.desc, h.nullsFirst):
+		return -1
+	case lessRow(b, a, h.colIdx, h.desc, h.nullsFirst):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Push offers row to the selector, discarding it once n rows that sort
+// at or before it have already been retained.
+func (h *HeapSelector) Push(row Row) {
+	if h.n <= 0 {
+		return
+	}
+	if h.rows.Len() < h.n {
+		heap.Push(&h.rows, heapEntry{row: row, h: h})
+		return
+	}
+	if h.compare(row, h.rows[0].row) >= 0 {
+		// row sorts at or after the current worst retained row; drop it.
+		return
+	}
+	h.rows[0] = heapEntry{row: row, h: h}
+	heap.Fix(&h.rows, 0)
+}
+
+// Drain pops the retained rows in sorted order, skips the first offset of
+// them, and appends the remainder to dst.
+func (h *HeapSelector) Drain(dst BlockBuilder, offset int) {
+	ordered := make([]Row, h.rows.Len())
+	for i := len(ordered) - 1; i >= 0; i-- {
+		ordered[i] = heap.Pop(&h.rows).(heapEntry).row
+	}
+	for i, row := range ordered {
+		if i < offset {
+			continue
+		}
+		dst.AppendRow(row)
+	}
+}
+
+type heapEntry struct {
+	row Row
+	h   *HeapSelector
+}
+
+// rowHeap is a max-heap (by the selector's comparator) so its root is
+// always the worst retained row, ready to be evicted in O(log n).
+type rowHeap []heapEntry
+
+func (r rowHeap) Len() int { return len(r) }
+func (r rowHeap) Less(i, j int) bool {
+	return r[i].h.compare(r[i].row, r[j].row) > 0
+}
+func (r rowHeap) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r *rowHeap) Push(x interface{}) {
+	*r = append(*r, x.(heapEntry))
+}
+func (r *rowHeap) Pop() interface{} {
+	old := *r
+	n := len(old)
+	item := old[n-1]
+	*r = old[:n-1]
+	return item
+}
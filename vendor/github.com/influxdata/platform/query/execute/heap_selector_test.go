@@ -0,0 +1,40 @@
+package execute
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHeapSelectorTopKAndOffset(t *testing.T) {
+	rows := []Row{{int64(5)}, {int64(1)}, {int64(9)}, {int64(3)}, {int64(7)}}
+
+	sel := NewHeapSelector([]int{0}, []bool{true}, []bool{false}, 3)
+	for _, r := range rows {
+		sel.Push(r)
+	}
+
+	dst := NewRowBuilder([]string{"_value"})
+	sel.Drain(dst, 1)
+
+	var got []int64
+	for i := 0; i < dst.NRows(); i++ {
+		got = append(got, dst.Row(i)[0].(int64))
+	}
+	// Top 3 desc are 9,7,5; offset 1 drops the 9, leaving 7,5.
+	want := []int64{7, 5}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestHeapSelectorNoLimit(t *testing.T) {
+	sel := NewHeapSelector([]int{0}, []bool{false}, []bool{false}, 0)
+	sel.Push(Row{int64(1)})
+
+	dst := NewRowBuilder([]string{"_value"})
+	sel.Drain(dst, 0)
+
+	if dst.NRows() != 0 {
+		t.Fatalf("got %d rows, want 0", dst.NRows())
+	}
+}
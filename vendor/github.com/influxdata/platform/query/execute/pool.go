@@ -0,0 +1,55 @@
+package execute
+
+import (
+	"errors"
+	"sync"
+)
+
+// Pool is a small bounded worker pool: submissions beyond its capacity
+// block until a slot frees up, which caps goroutine growth the way a
+// sharded sort needs when fanning a block's rows out across CPUs.
+type Pool struct {
+	sem chan struct{}
+	wg  sync.WaitGroup
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// NewPool returns a Pool that runs at most size submitted functions
+// concurrently.
+func NewPool(size int) (*Pool, error) {
+	if size <= 0 {
+		return nil, errors.New("execute: pool size must be positive")
+	}
+	return &Pool{sem: make(chan struct{}, size)}, nil
+}
+
+// Submit runs fn on the pool, blocking until a slot is available. It
+// returns an error without running fn if the pool has been released.
+func (p *Pool) Submit(fn func()) error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return errors.New("execute: pool is closed")
+	}
+	p.wg.Add(1)
+	p.mu.Unlock()
+
+	p.sem <- struct{}{}
+	go func() {
+		defer p.wg.Done()
+		defer func() { <-p.sem }()
+		fn()
+	}()
+	return nil
+}
+
+// Release waits for every submitted function to finish and marks the
+// pool closed; further Submit calls fail.
+func (p *Pool) Release() {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+	p.wg.Wait()
+}
@@ -0,0 +1,40 @@
+package execute
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestPoolRunsSubmittedWork(t *testing.T) {
+	pool, err := NewPool(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	sum := 0
+	for i := 1; i <= 100; i++ {
+		i := i
+		if err := pool.Submit(func() {
+			mu.Lock()
+			sum += i
+			mu.Unlock()
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pool.Release()
+
+	if sum != 5050 {
+		t.Fatalf("got sum %d, want 5050", sum)
+	}
+	if err := pool.Submit(func() {}); err == nil {
+		t.Fatal("expected Submit on a released pool to fail")
+	}
+}
+
+func TestNewPoolRejectsNonPositiveSize(t *testing.T) {
+	if _, err := NewPool(0); err == nil {
+		t.Fatal("expected an error for a non-positive pool size")
+	}
+}
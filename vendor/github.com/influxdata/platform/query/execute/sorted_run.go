@@ -0,0 +1,89 @@
+package execute
+
+import "container/heap"
+
+// SortedRun is one contiguously-sorted shard of a block's rows, produced
+// by sharding a sort across a worker pool so the shards can later be
+// merged back together.
+type SortedRun struct {
+	rows []Row
+}
+
+// NewSortedRun copies builder's rows in [start, stop) and sorts them
+// using the same stable, multi-key comparator as the single-threaded
+// path.
+func NewSortedRun(builder BlockBuilder, start, stop int, cols []string, desc, nullsFirst []bool) (*SortedRun, error) {
+	rows := make([]Row, stop-start)
+	for i := start; i < stop; i++ {
+		rows[i-start] = builder.Row(i)
+	}
+	sortRows(rows, colIndices(builder, cols), desc, nullsFirst)
+	return &SortedRun{rows: rows}, nil
+}
+
+// MergeSortedRuns k-way merges runs (each already individually sorted)
+// back into dst in the same stable order, using a min-heap over the run
+// heads so no run is fully materialized more than once.
+func MergeSortedRuns(dst BlockBuilder, runs []*SortedRun, cols []string, desc, nullsFirst []bool) error {
+	dst.ClearData()
+	idx := colIndices(dst, cols)
+
+	h := make(runHeap, 0, len(runs))
+	for i, run := range runs {
+		if len(run.rows) > 0 {
+			heap.Push(&h, runHead{row: run.rows[0], run: i, pos: 0, idx: idx, desc: desc, nullsFirst: nullsFirst})
+		}
+	}
+	for h.Len() > 0 {
+		top := heap.Pop(&h).(runHead)
+		dst.AppendRow(top.row)
+		if next := top.pos + 1; next < len(runs[top.run].rows) {
+			heap.Push(&h, runHead{row: runs[top.run].rows[next], run: top.run, pos: next, idx: idx, desc: desc, nullsFirst: nullsFirst})
+		}
+	}
+	return nil
+}
+
+// runHead is one run's current candidate row in a k-way merge.
+type runHead struct {
+	row        Row
+	run, pos   int
+	idx        []int
+	desc       []bool
+	nullsFirst []bool
+}
+
+type runHeap []runHead
+
+func (r runHeap) Len() int { return len(r) }
+
+// Less breaks ties on (run, pos) so that when two rows from different
+// runs compare equal on the sort key, the one from the earlier run (and,
+// within a run, the earlier position) comes out first. Runs are built in
+// original row order, so this keeps equal keys in their input order
+// overall, matching the stable-sort guarantee the single-threaded path
+// gets for free from sort.SliceStable.
+func (r runHeap) Less(i, j int) bool {
+	a, b := r[i], r[j]
+	if lessRow(a.row, b.row, a.idx, a.desc, a.nullsFirst) {
+		return true
+	}
+	if lessRow(b.row, a.row, b.idx, b.desc, b.nullsFirst) {
+		return false
+	}
+	if a.run != b.run {
+		return a.run < b.run
+	}
+	return a.pos < b.pos
+}
+func (r runHeap) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r *runHeap) Push(x interface{}) {
+	*r = append(*r, x.(runHead))
+}
+func (r *runHeap) Pop() interface{} {
+	old := *r
+	n := len(old)
+	item := old[n-1]
+	*r = old[:n-1]
+	return item
+}
@@ -0,0 +1,178 @@
+package execute
+
+import (
+	"bufio"
+	"compress/gzip"
+	"container/heap"
+	"encoding/gob"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+func init() {
+	gob.Register("")
+	gob.Register(float64(0))
+	gob.Register(int64(0))
+	gob.Register(false)
+	gob.Register(time.Time{})
+}
+
+// SpillRun is a single sorted run of rows that has been flushed to a
+// temporary file on disk as one gob-encoded row per message, so a block
+// larger than the configured memory budget doesn't have to stay fully
+// resident either to be sorted or, via reader, to be merged back in.
+type SpillRun struct {
+	path     string
+	compress bool
+}
+
+// NewSpillRun sorts builder's currently-buffered rows and streams them to
+// a new temporary file under dir one row at a time (gob's wire format
+// length-prefixes every encoded value, so this needs no framing of its
+// own), optionally gzip-compressed.
+func NewSpillRun(dir string, builder BlockBuilder, cols []string, desc, nullsFirst []bool, compress bool) (*SpillRun, error) {
+	rows := make([]Row, builder.NRows())
+	for i := range rows {
+		rows[i] = builder.Row(i)
+	}
+	sortRows(rows, colIndices(builder, cols), desc, nullsFirst)
+
+	f, err := ioutil.TempFile(dir, "sort-run-")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	bw := bufio.NewWriter(f)
+	var w io.Writer = bw
+	var gz *gzip.Writer
+	if compress {
+		gz = gzip.NewWriter(bw)
+		w = gz
+	}
+
+	enc := gob.NewEncoder(w)
+	for _, row := range rows {
+		if err := enc.Encode(&row); err != nil {
+			os.Remove(f.Name())
+			return nil, err
+		}
+	}
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			os.Remove(f.Name())
+			return nil, err
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		os.Remove(f.Name())
+		return nil, err
+	}
+	return &SpillRun{path: f.Name(), compress: compress}, nil
+}
+
+// reader opens the run for a single streaming pass over its rows.
+func (r *SpillRun) reader() (*spillRunReader, error) {
+	f, err := os.Open(r.path)
+	if err != nil {
+		return nil, err
+	}
+	var rd io.Reader = bufio.NewReader(f)
+	sr := &spillRunReader{f: f}
+	if r.compress {
+		gz, err := gzip.NewReader(rd)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		sr.gz = gz
+		rd = gz
+	}
+	sr.dec = gob.NewDecoder(rd)
+	return sr, nil
+}
+
+// Close removes the run's temporary file.
+func (r *SpillRun) Close() error {
+	return os.Remove(r.path)
+}
+
+// spillRunReader decodes a run's rows one at a time instead of requiring
+// the whole run to be in memory at once, so merging many runs only ever
+// holds one row per run.
+type spillRunReader struct {
+	f   *os.File
+	gz  *gzip.Reader
+	dec *gob.Decoder
+}
+
+// next decodes the reader's next row, returning io.EOF once the run is
+// exhausted.
+func (sr *spillRunReader) next() (Row, error) {
+	var row Row
+	if err := sr.dec.Decode(&row); err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+func (sr *spillRunReader) Close() error {
+	if sr.gz != nil {
+		sr.gz.Close()
+	}
+	return sr.f.Close()
+}
+
+// MergeSpillRuns k-way merges runs (each individually sorted) back into
+// dst, using a heap over the runs' streaming readers keyed by the same
+// multi-key comparator as the in-memory sort. Each run is read one row at
+// a time, so peak memory is bounded by the number of runs, not their
+// total size.
+func MergeSpillRuns(dst BlockBuilder, runs []*SpillRun, cols []string, desc, nullsFirst []bool) error {
+	dst.ClearData()
+	idx := colIndices(dst, cols)
+
+	readers := make([]*spillRunReader, len(runs))
+	for i, run := range runs {
+		r, err := run.reader()
+		if err != nil {
+			return err
+		}
+		readers[i] = r
+	}
+	defer func() {
+		for _, r := range readers {
+			r.Close()
+		}
+	}()
+
+	h := make(runHeap, 0, len(runs))
+	for i, r := range readers {
+		row, err := r.next()
+		if err == io.EOF {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		h = append(h, runHead{row: row, run: i, pos: 0, idx: idx, desc: desc, nullsFirst: nullsFirst})
+	}
+	heap.Init(&h)
+
+	for h.Len() > 0 {
+		top := heap.Pop(&h).(runHead)
+		dst.AppendRow(top.row)
+
+		row, err := readers[top.run].next()
+		if err == io.EOF {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		heap.Push(&h, runHead{row: row, run: top.run, pos: top.pos + 1, idx: idx, desc: desc, nullsFirst: nullsFirst})
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package execute
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSpillRunRoundTrip(t *testing.T) {
+	builder := NewRowBuilder([]string{"_value"})
+	for _, v := range []int64{5, 1, 4, 2, 3} {
+		builder.AppendRow(Row{v})
+	}
+
+	for _, compress := range []bool{false, true} {
+		run, err := NewSpillRun(t.TempDir(), builder, []string{"_value"}, []bool{false}, []bool{false}, compress)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer run.Close()
+
+		dst := NewRowBuilder([]string{"_value"})
+		if err := MergeSpillRuns(dst, []*SpillRun{run}, []string{"_value"}, []bool{false}, []bool{false}); err != nil {
+			t.Fatal(err)
+		}
+
+		var got []int64
+		for i := 0; i < dst.NRows(); i++ {
+			got = append(got, dst.Row(i)[0].(int64))
+		}
+		want := []int64{1, 2, 3, 4, 5}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("compress=%v: got %v, want %v", compress, got, want)
+		}
+	}
+}
+
+func TestMergeSpillRunsManyRuns(t *testing.T) {
+	dir := t.TempDir()
+	var runs []*SpillRun
+	for shard := 0; shard < 3; shard++ {
+		b := NewRowBuilder([]string{"_value"})
+		for i := 0; i < 5; i++ {
+			b.AppendRow(Row{int64(shard*5 + i)})
+		}
+		run, err := NewSpillRun(dir, b, []string{"_value"}, []bool{true}, []bool{false}, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer run.Close()
+		runs = append(runs, run)
+	}
+
+	dst := NewRowBuilder([]string{"_value"})
+	if err := MergeSpillRuns(dst, runs, []string{"_value"}, []bool{true}, []bool{false}); err != nil {
+		t.Fatal(err)
+	}
+	if dst.NRows() != 15 {
+		t.Fatalf("got %d rows, want 15", dst.NRows())
+	}
+	for i := 1; i < dst.NRows(); i++ {
+		if dst.Row(i-1)[0].(int64) < dst.Row(i)[0].(int64) {
+			t.Fatalf("rows not sorted desc at %d: %v then %v", i, dst.Row(i-1)[0], dst.Row(i)[0])
+		}
+	}
+}
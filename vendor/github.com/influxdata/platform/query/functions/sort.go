@@ -1,7 +1,10 @@
 package functions
 
 import (
+	"errors"
 	"fmt"
+	"runtime"
+	"sync"
 
 	"github.com/influxdata/platform/query"
 	"github.com/influxdata/platform/query/execute"
@@ -13,15 +16,38 @@ import (
 
 const SortKind = "sort"
 
+// SortKeySpec describes a single key of a multi-key sort: the column to
+// sort by, its direction, and where null values should fall relative to
+// the rest of that column's values.
+type SortKeySpec struct {
+	Column     string `json:"col"`
+	Desc       bool   `json:"desc"`
+	NullsFirst bool   `json:"nullsFirst"`
+}
+
 type SortOpSpec struct {
-	Cols []string `json:"cols"`
-	Desc bool     `json:"desc"`
+	Cols         []string      `json:"cols"`
+	Desc         bool          `json:"desc"`
+	By           []SortKeySpec `json:"by"`
+	Limit        int64         `json:"limit"`
+	Offset       int64         `json:"offset"`
+	Parallelism  int64         `json:"parallelism"`
+	SpillDir     string        `json:"spillDir"`
+	MemoryBudget int64         `json:"memoryBudget"`
+	Compress     bool          `json:"compress"`
 }
 
 var sortSignature = query.DefaultFunctionSignature()
 
 func init() {
 	sortSignature.Params["cols"] = semantic.NewArrayType(semantic.String)
+	sortSignature.Params["by"] = semantic.NewArrayType(semantic.Object)
+	sortSignature.Params["limit"] = semantic.Int
+	sortSignature.Params["offset"] = semantic.Int
+	sortSignature.Params["parallelism"] = semantic.Int
+	sortSignature.Params["spillDir"] = semantic.String
+	sortSignature.Params["memoryBudget"] = semantic.Int
+	sortSignature.Params["compress"] = semantic.Bool
 
 	query.RegisterFunction(SortKind, createSortOpSpec, sortSignature)
 	query.RegisterOpSpec(SortKind, newSortOp)
@@ -36,6 +62,16 @@ func createSortOpSpec(args query.Arguments, a *query.Administration) (query.Oper
 
 	spec := new(SortOpSpec)
 
+	if array, ok, err := args.GetArray("by", semantic.Object); err != nil {
+		return nil, err
+	} else if ok {
+		keys, err := toSortKeySpecs(array)
+		if err != nil {
+			return nil, err
+		}
+		spec.By = keys
+	}
+
 	if array, ok, err := args.GetArray("cols", semantic.String); err != nil {
 		return nil, err
 	} else if ok {
@@ -43,7 +79,7 @@ func createSortOpSpec(args query.Arguments, a *query.Administration) (query.Oper
 		if err != nil {
 			return nil, err
 		}
-	} else {
+	} else if len(spec.By) == 0 {
 		//Default behavior to sort by value
 		spec.Cols = []string{execute.DefaultValueColLabel}
 	}
@@ -54,9 +90,84 @@ func createSortOpSpec(args query.Arguments, a *query.Administration) (query.Oper
 		spec.Desc = desc
 	}
 
+	if limit, ok, err := args.GetInt("limit"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Limit = limit
+	}
+
+	if offset, ok, err := args.GetInt("offset"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Offset = offset
+	}
+
+	if spec.Offset > 0 && spec.Limit <= 0 {
+		return nil, errors.New("sort: offset requires a positive limit")
+	}
+
+	if parallelism, ok, err := args.GetInt("parallelism"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Parallelism = parallelism
+	}
+
+	if spillDir, ok, err := args.GetString("spillDir"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.SpillDir = spillDir
+	}
+
+	if memoryBudget, ok, err := args.GetInt("memoryBudget"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.MemoryBudget = memoryBudget
+	}
+
+	if compress, ok, err := args.GetBool("compress"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Compress = compress
+	}
+
 	return spec, nil
 }
 
+// toSortKeySpecs converts the `by` argument, an array of
+// `{col, desc, nullsFirst}` records, into the list of SortKeySpec used by
+// the rest of the transformation.
+func toSortKeySpecs(array values.Array) ([]SortKeySpec, error) {
+	keys := make([]SortKeySpec, array.Len())
+	var rangeErr error
+	array.Range(func(i int, v values.Value) {
+		if rangeErr != nil {
+			return
+		}
+		obj := v.Object()
+		col, ok := obj.Get("col")
+		if !ok {
+			rangeErr = errors.New(`sort: "by" entries require a "col" field`)
+			return
+		}
+		if col.Type() != semantic.String {
+			rangeErr = fmt.Errorf(`sort: "by" entry "col" field must be a string, got %v`, col.Type())
+			return
+		}
+		key := SortKeySpec{Column: col.Str()}
+		if desc, ok := obj.Get("desc"); ok {
+			key.Desc = desc.Bool()
+		}
+		if nullsFirst, ok := obj.Get("nullsFirst"); ok {
+			key.NullsFirst = nullsFirst.Bool()
+		}
+		keys[i] = key
+	})
+	if rangeErr != nil {
+		return nil, rangeErr
+	}
+	return keys, nil
+}
+
 func newSortOp() query.OperationSpec {
 	return new(SortOpSpec)
 }
@@ -66,8 +177,16 @@ func (s *SortOpSpec) Kind() query.OperationKind {
 }
 
 type SortProcedureSpec struct {
-	Cols []string
-	Desc bool
+	Cols        []string
+	Desc        bool
+	By          []SortKeySpec
+	Limit       int64
+	Offset      int64
+	Parallelism int64
+
+	SpillDir     string
+	MemoryBudget int64
+	Compress     bool
 }
 
 func newSortProcedure(qs query.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
@@ -77,8 +196,15 @@ func newSortProcedure(qs query.OperationSpec, pa plan.Administration) (plan.Proc
 	}
 
 	return &SortProcedureSpec{
-		Cols: spec.Cols,
-		Desc: spec.Desc,
+		Cols:         spec.Cols,
+		Desc:         spec.Desc,
+		By:           spec.By,
+		Limit:        spec.Limit,
+		Offset:       spec.Offset,
+		Parallelism:  spec.Parallelism,
+		SpillDir:     spec.SpillDir,
+		MemoryBudget: spec.MemoryBudget,
+		Compress:     spec.Compress,
 	}, nil
 }
 
@@ -92,9 +218,36 @@ func (s *SortProcedureSpec) Copy() plan.ProcedureSpec {
 	copy(ns.Cols, s.Cols)
 
 	ns.Desc = s.Desc
+
+	ns.By = make([]SortKeySpec, len(s.By))
+	copy(ns.By, s.By)
+
+	ns.Limit = s.Limit
+	ns.Offset = s.Offset
+	ns.Parallelism = s.Parallelism
+
+	ns.SpillDir = s.SpillDir
+	ns.MemoryBudget = s.MemoryBudget
+	ns.Compress = s.Compress
+
 	return ns
 }
 
+// keys returns the effective multi-key sort specification. The legacy
+// cols/desc form is translated into per-key specs (each inheriting the
+// single global direction) whenever `by` was not provided, so the rest of
+// the transformation only ever has to deal with one representation.
+func (s *SortProcedureSpec) keys() []SortKeySpec {
+	if len(s.By) > 0 {
+		return s.By
+	}
+	keys := make([]SortKeySpec, len(s.Cols))
+	for i, c := range s.Cols {
+		keys[i] = SortKeySpec{Column: c, Desc: s.Desc}
+	}
+	return keys
+}
+
 func createSortTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
 	s, ok := spec.(*SortProcedureSpec)
 	if !ok {
@@ -102,33 +255,112 @@ func createSortTransformation(id execute.DatasetID, mode execute.AccumulationMod
 	}
 	cache := execute.NewBlockBuilderCache(a.Allocator())
 	d := execute.NewDataset(id, mode, cache)
-	t := NewSortTransformation(d, cache, s)
+	t, err := NewSortTransformation(d, cache, s, a.Allocator())
+	if err != nil {
+		return nil, nil, err
+	}
 	return t, d, nil
 }
 
+// keyRuns tracks the spilled, individually-sorted runs that belong to a
+// single partition key, so Finish can merge them back together once all of
+// a key's blocks have been seen.
+type keyRuns struct {
+	key  query.PartitionKey
+	runs []*execute.SpillRun
+}
+
 type sortTransformation struct {
 	d     execute.Dataset
 	cache execute.BlockBuilderCache
+	alloc execute.Allocator
 
+	keys []SortKeySpec
 	cols []string
-	desc bool
+
+	limit  int64
+	offset int64
+
+	// parallelism and pool back the sharded sort path: the pool is built
+	// once per transformation and released in Finish so that sorting a
+	// single large block can fan out across workers instead of serializing
+	// on one goroutine.
+	parallelism int
+	pool        *execute.Pool
+
+	// spillDir/memoryBudget/compress configure the external-sort path: once
+	// the allocator reports more than memoryBudget bytes in use, buffered
+	// rows are sorted and flushed to spillDir instead of growing the
+	// builder further. A memoryBudget of 0 disables spilling entirely.
+	spillDir     string
+	memoryBudget int64
+	compress     bool
+	spills       []*keyRuns
 
 	colMap []int
 }
 
-func NewSortTransformation(d execute.Dataset, cache execute.BlockBuilderCache, spec *SortProcedureSpec) *sortTransformation {
+func NewSortTransformation(d execute.Dataset, cache execute.BlockBuilderCache, spec *SortProcedureSpec, alloc execute.Allocator) (*sortTransformation, error) {
+	keys := spec.keys()
+	cols := make([]string, len(keys))
+	for i, k := range keys {
+		cols[i] = k.Column
+	}
+
+	parallelism := int(spec.Parallelism)
+	if parallelism <= 0 {
+		parallelism = runtime.NumCPU()
+	}
+	pool, err := execute.NewPool(parallelism)
+	if err != nil {
+		return nil, err
+	}
+
 	return &sortTransformation{
-		d:     d,
-		cache: cache,
-		cols:  spec.Cols,
-		desc:  spec.Desc,
+		d:            d,
+		cache:        cache,
+		alloc:        alloc,
+		keys:         keys,
+		cols:         cols,
+		limit:        spec.Limit,
+		offset:       spec.Offset,
+		parallelism:  parallelism,
+		pool:         pool,
+		spillDir:     spec.SpillDir,
+		memoryBudget: spec.MemoryBudget,
+		compress:     spec.Compress,
+	}, nil
+}
+
+// runsFor returns the keyRuns tracking key's spilled runs, creating one on
+// first use.
+func (t *sortTransformation) runsFor(key query.PartitionKey) *keyRuns {
+	for _, kr := range t.spills {
+		if kr.key.Equal(key) {
+			return kr
+		}
 	}
+	kr := &keyRuns{key: key}
+	t.spills = append(t.spills, kr)
+	return kr
 }
 
 func (t *sortTransformation) RetractBlock(id execute.DatasetID, key query.PartitionKey) error {
 	return t.d.RetractBlock(key)
 }
 
+// sortOptions splits the transformation's per-key spec into the parallel
+// desc/nullsFirst slices that builder.SortWithOptions expects.
+func (t *sortTransformation) sortOptions() (desc, nullsFirst []bool) {
+	desc = make([]bool, len(t.keys))
+	nullsFirst = make([]bool, len(t.keys))
+	for i, k := range t.keys {
+		desc[i] = k.Desc
+		nullsFirst[i] = k.NullsFirst
+	}
+	return desc, nullsFirst
+}
+
 func (t *sortTransformation) Process(id execute.DatasetID, b query.Block) error {
 	key := b.Key()
 	for _, label := range t.cols {
@@ -154,12 +386,156 @@ func (t *sortTransformation) Process(id execute.DatasetID, b query.Block) error
 		t.colMap = t.colMap[:ncols]
 	}
 
+	desc, nullsFirst := t.sortOptions()
+
+	// When a limit is set we avoid materializing the whole block: each
+	// chunk is staged into builder just long enough to push its rows
+	// through a bounded heap of size limit+offset, then truncated back
+	// out, so peak memory is one chunk plus the retained rows, not the
+	// whole block. Only the rows the heap kept are written back into
+	// builder, already in sorted order.
+	if t.limit > 0 {
+		colIdx := make([]int, len(t.cols))
+		for i, c := range t.cols {
+			colIdx[i] = builder.ColIndex(c)
+		}
+		selector := execute.NewHeapSelector(colIdx, desc, nullsFirst, int(t.limit+t.offset))
+
+		if err := b.Do(func(cr query.ColReader) error {
+			before := builder.NRows()
+			execute.AppendCols(cr, builder, t.colMap)
+			for i := before; i < builder.NRows(); i++ {
+				selector.Push(builder.Row(i))
+			}
+			builder.Truncate(before)
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		selector.Drain(builder, int(t.offset))
+		return nil
+	}
+
+	// When a memory budget is configured, stream the block's chunks into
+	// builder one at a time, spilling a sorted run to disk whenever the
+	// allocator reports usage past the budget, instead of requiring the
+	// whole block to fit in the builder at once.
+	if t.memoryBudget > 0 {
+		run := t.runsFor(key)
+		if err := b.Do(func(cr query.ColReader) error {
+			execute.AppendCols(cr, builder, t.colMap)
+			if t.alloc.Allocated() <= t.memoryBudget {
+				return nil
+			}
+			spilled, err := t.spill(builder, desc, nullsFirst)
+			if err != nil {
+				return err
+			}
+			run.runs = append(run.runs, spilled)
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		// The block never crossing the budget (the common case) and the
+		// tail left buffered after the last spill both leave rows sitting
+		// unsorted in builder: if nothing was ever spilled, sort builder
+		// in place and we're done; otherwise spill what's left as one
+		// final run so mergeSpills sees every row, not just the earlier
+		// ones.
+		if len(run.runs) == 0 {
+			builder.SortWithOptions(t.cols, desc, nullsFirst)
+			return nil
+		}
+		if builder.NRows() > 0 {
+			spilled, err := t.spill(builder, desc, nullsFirst)
+			if err != nil {
+				return err
+			}
+			run.runs = append(run.runs, spilled)
+		}
+		return nil
+	}
+
 	execute.AppendBlock(b, builder, t.colMap)
 
-	builder.Sort(t.cols, t.desc)
+	if t.parallelism > 1 {
+		return t.sortSharded(builder, desc, nullsFirst)
+	}
+	builder.SortWithOptions(t.cols, desc, nullsFirst)
 	return nil
 }
 
+// spill flushes whatever is currently buffered in builder to a new sorted
+// run file under t.spillDir, then clears builder so the next chunk starts
+// from empty. NewSpillRun sorts the rows itself, so the Finish-time merge
+// only has to stream, not re-sort, their rows.
+func (t *sortTransformation) spill(builder execute.BlockBuilder, desc, nullsFirst []bool) (*execute.SpillRun, error) {
+	run, err := execute.NewSpillRun(t.spillDir, builder, t.cols, desc, nullsFirst, t.compress)
+	if err != nil {
+		return nil, err
+	}
+	builder.ClearData()
+	return run, nil
+}
+
+// sortSharded partitions builder's rows into t.parallelism roughly equal
+// ranges, sorts each range concurrently on the transformation's worker
+// pool, and k-way merges the sorted runs back into builder using a
+// min-heap over the run heads. Each range is sorted with the same stable
+// comparator as the single-threaded path and runs are merged in their
+// original order, so equal keys keep their input order overall.
+func (t *sortTransformation) sortSharded(builder execute.BlockBuilder, desc, nullsFirst []bool) error {
+	n := builder.NRows()
+	shards := t.parallelism
+	if shards > n {
+		shards = n
+	}
+	if shards <= 1 {
+		builder.SortWithOptions(t.cols, desc, nullsFirst)
+		return nil
+	}
+
+	size := (n + shards - 1) / shards
+	runs := make([]*execute.SortedRun, shards)
+	errs := make([]error, shards)
+
+	var wg sync.WaitGroup
+	for i := 0; i < shards; i++ {
+		i := i
+		start := i * size
+		stop := start + size
+		if stop > n {
+			stop = n
+		}
+
+		wg.Add(1)
+		err := t.pool.Submit(func() {
+			defer wg.Done()
+			runs[i], errs[i] = execute.NewSortedRun(builder, start, stop, t.cols, desc, nullsFirst)
+		})
+		if err != nil {
+			// Shards submitted before this one are still running against
+			// builder; wait for them to finish before returning so the
+			// caller doesn't release the pool or touch builder while they
+			// still read from it.
+			wg.Done()
+			wg.Wait()
+			return err
+		}
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return execute.MergeSortedRuns(builder, runs, t.cols, desc, nullsFirst)
+}
+
 func (t *sortTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
 	return t.d.UpdateWatermark(mark)
 }
@@ -167,9 +543,40 @@ func (t *sortTransformation) UpdateProcessingTime(id execute.DatasetID, pt execu
 	return t.d.UpdateProcessingTime(pt)
 }
 func (t *sortTransformation) Finish(id execute.DatasetID, err error) {
+	if err == nil {
+		err = t.mergeSpills()
+	}
+	for _, kr := range t.spills {
+		for _, run := range kr.runs {
+			run.Close()
+		}
+	}
+	t.pool.Release()
 	t.d.Finish(err)
 }
 
+// mergeSpills performs, for every key that spilled at least one run, an
+// on-disk k-way merge of its runs into a fresh BlockBuilder. The merge uses
+// a heap over the run readers keyed by the same multi-key comparator as the
+// in-memory sort, so spilling doesn't change the result, only how it's
+// computed.
+func (t *sortTransformation) mergeSpills() error {
+	desc, nullsFirst := t.sortOptions()
+	for _, kr := range t.spills {
+		if len(kr.runs) == 0 {
+			continue
+		}
+		builder, _ := t.cache.BlockBuilder(kr.key)
+		if err := execute.MergeSpillRuns(builder, kr.runs, t.cols, desc, nullsFirst); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortedKey reorders key so that any of its columns that are also sort
+// keys come first, in the same order they appear in t.cols, followed by
+// the remaining columns in their original order.
 func (t *sortTransformation) sortedKey(key query.PartitionKey) query.PartitionKey {
 	cols := make([]query.ColMeta, len(key.Cols()))
 	vs := make([]values.Value, len(key.Cols()))